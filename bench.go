@@ -2,27 +2,57 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"math"
 	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"runtime"
+	"runtime/pprof"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
+// shutdownGrace bounds how long a benchmark waits for in-flight ops to drain
+// once its context is canceled (run-time deadline elapsed, or SIGINT/SIGTERM).
+const shutdownGrace = 3 * time.Second
+
 type Config struct {
-	Tasks       int
-	Concurrency int
-	Repeats     int
-	Warmup      int
-	CpuUnits    int
-	PayloadSize int
-	BacklogHint int
-	TimeoutMs   int
+	Tasks          int
+	Concurrency    int
+	Repeats        int
+	Warmup         int
+	CpuUnits       int
+	PayloadSize    int
+	BacklogHint    int
+	TimeoutMs      int
+	Report         string
+	RunTime        time.Duration
+	Workload       string
+	WorkloadTarget string
+	HTTPMethod     string
+	BlobSizeMiB    int
+	Output         string
+	OutputFile     string
+	Compare        string
+	SigThreshold   float64
+	IOMode         string
+	PipelineDepth  int
 }
 
 func parseArgs() Config {
@@ -35,10 +65,43 @@ func parseArgs() Config {
 	flag.IntVar(&c.PayloadSize, "payload-size", 256, "Bytes per I/O request.")
 	flag.IntVar(&c.BacklogHint, "backlog", 4096, "Server accept backlog hint (best effort, OS dependent).")
 	flag.IntVar(&c.TimeoutMs, "timeout-ms", 20000, "Dial/read/write timeout in milliseconds.")
+	flag.StringVar(&c.Report, "report", "table", "Per-op latency report detail: table|histogram|both.")
+	flag.DurationVar(&c.RunTime, "run-time", 0, "Keep issuing work until this duration elapses, as a single deadline for the whole --repeats run, not per repeat (0 disables; mutually usable with --tasks).")
+	flag.StringVar(&c.Workload, "workload", "tcp-echo", "I/O workload: tcp-echo|http|unix|udp|blob.")
+	flag.StringVar(&c.WorkloadTarget, "workload-target", "", "Workload target: required URL for http, optional socket path for unix. Unused by tcp-echo, udp, blob.")
+	flag.StringVar(&c.HTTPMethod, "workload-http-method", "GET", "HTTP method for --workload=http: GET|PUT.")
+	flag.IntVar(&c.BlobSizeMiB, "blob-size-mib", 1, "MiB streamed per op for --workload=blob.")
+	flag.StringVar(&c.Output, "output", "text", "Report format: text|json|csv. json/csv suppress the human-readable tables.")
+	flag.StringVar(&c.OutputFile, "output-file", "", "Write --output to this path instead of stdout (ignored for --output=text).")
+	flag.StringVar(&c.Compare, "compare", "", "Path to a prior --output=json run; prints a regression delta table against it.")
+	flag.Float64Var(&c.SigThreshold, "sig-threshold", 0.05, "Mann-Whitney U two-sided p-value threshold for flagging a regression as significant.")
+	flag.StringVar(&c.IOMode, "io-mode", "dial-per-op", "I/O connection mode: dial-per-op|keepalive|pipeline. keepalive/pipeline reuse a per-worker connection (threads, goroutines); the processes model and workloads without a persistent connection (http, blob) always fall back to dial-per-op.")
+	flag.IntVar(&c.PipelineDepth, "pipeline-depth", 8, "Outstanding requests per batch for --io-mode=pipeline.")
 	flag.Parse()
 	return c
 }
 
+// waitForDrain waits for wg to finish. Once ctx is canceled (before or during the wait), the
+// remaining wait is bounded by shutdownGrace so a hung op can't block shutdown forever.
+func waitForDrain(ctx context.Context, wg *sync.WaitGroup) {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+
+	select {
+	case <-done:
+	case <-time.After(shutdownGrace):
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -47,21 +110,121 @@ func min(a, b int) int {
 }
 
 type Result struct {
-	Model string
-	Runs  []time.Duration
+	Model     string
+	Runs      []time.Duration
+	Ops       []time.Duration
+	Resources []ResourceSample
 }
 
 func (r Result) Median() time.Duration { return median(r.Runs) }
 func (r Result) Min() time.Duration    { return minDur(r.Runs) }
 func (r Result) Max() time.Duration    { return maxDur(r.Runs) }
 
+func (r Result) OpMean() time.Duration                { return mean(r.Ops) }
+func (r Result) OpMin() time.Duration                 { return minDur(r.Ops) }
+func (r Result) OpMax() time.Duration                 { return maxDur(r.Ops) }
+func (r Result) OpStdDev() time.Duration              { return stddev(r.Ops) }
+func (r Result) OpPercentile(p float64) time.Duration { return percentile(r.Ops, p) }
+
+func (r Result) cpuPercentSamples() []float64 {
+	out := make([]float64, len(r.Resources))
+	for i, s := range r.Resources {
+		out[i] = s.CPUPercent
+	}
+	return out
+}
+
+func (r Result) rssSamples() []uint64 {
+	out := make([]uint64, len(r.Resources))
+	for i, s := range r.Resources {
+		out[i] = s.RSSBytes
+	}
+	return out
+}
+
+func (r Result) goroutineSamples() []int {
+	out := make([]int, len(r.Resources))
+	for i, s := range r.Resources {
+		out[i] = s.Goroutines
+	}
+	return out
+}
+
+func (r Result) osThreadSamples() []int {
+	out := make([]int, len(r.Resources))
+	for i, s := range r.Resources {
+		out[i] = s.OSThreads
+	}
+	return out
+}
+
+func (r Result) fdSamples() []int {
+	out := make([]int, len(r.Resources))
+	for i, s := range r.Resources {
+		out[i] = s.FDs
+	}
+	return out
+}
+
+// Throughput reports completed ops per second of measured wall time across all repeats.
+func (r Result) Throughput() float64 {
+	var total time.Duration
+	for _, d := range r.Runs {
+		total += d
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(len(r.Ops)) / total.Seconds()
+}
+
 func median(xs []time.Duration) time.Duration {
+	if len(xs) == 0 {
+		return 0
+	}
 	tmp := append([]time.Duration(nil), xs...)
 	sort.Slice(tmp, func(i, j int) bool { return tmp[i] < tmp[j] })
 	return tmp[len(tmp)/2]
 }
 
+func percentile(xs []time.Duration, p float64) time.Duration {
+	if len(xs) == 0 {
+		return 0
+	}
+	tmp := append([]time.Duration(nil), xs...)
+	sort.Slice(tmp, func(i, j int) bool { return tmp[i] < tmp[j] })
+	idx := int(p / 100 * float64(len(tmp)-1))
+	return tmp[idx]
+}
+
+func mean(xs []time.Duration) time.Duration {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / time.Duration(len(xs))
+}
+
+func stddev(xs []time.Duration) time.Duration {
+	if len(xs) == 0 {
+		return 0
+	}
+	m := mean(xs)
+	var sumSq float64
+	for _, x := range xs {
+		d := float64(x - m)
+		sumSq += d * d
+	}
+	return time.Duration(math.Sqrt(sumSq / float64(len(xs))))
+}
+
 func minDur(xs []time.Duration) time.Duration {
+	if len(xs) == 0 {
+		return 0
+	}
 	m := xs[0]
 	for _, x := range xs[1:] {
 		if x < m {
@@ -72,6 +235,9 @@ func minDur(xs []time.Duration) time.Duration {
 }
 
 func maxDur(xs []time.Duration) time.Duration {
+	if len(xs) == 0 {
+		return 0
+	}
 	m := xs[0]
 	for _, x := range xs[1:] {
 		if x > m {
@@ -81,10 +247,97 @@ func maxDur(xs []time.Duration) time.Duration {
 	return m
 }
 
+func medianFloat(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	tmp := append([]float64(nil), xs...)
+	sort.Float64s(tmp)
+	return tmp[len(tmp)/2]
+}
+
+func peakFloat(xs []float64) float64 {
+	var m float64
+	for _, x := range xs {
+		if x > m {
+			m = x
+		}
+	}
+	return m
+}
+
+func medianUint64(xs []uint64) uint64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	tmp := append([]uint64(nil), xs...)
+	sort.Slice(tmp, func(i, j int) bool { return tmp[i] < tmp[j] })
+	return tmp[len(tmp)/2]
+}
+
+func peakUint64(xs []uint64) uint64 {
+	var m uint64
+	for _, x := range xs {
+		if x > m {
+			m = x
+		}
+	}
+	return m
+}
+
+func medianInt(xs []int) int {
+	if len(xs) == 0 {
+		return 0
+	}
+	tmp := append([]int(nil), xs...)
+	sort.Ints(tmp)
+	return tmp[len(tmp)/2]
+}
+
+func peakInt(xs []int) int {
+	var m int
+	for _, x := range xs {
+		if x > m {
+			m = x
+		}
+	}
+	return m
+}
+
+// mergeLatencies flattens the lock-free per-worker latency slices collected during a run.
+func mergeLatencies(perWorker [][]time.Duration) []time.Duration {
+	total := 0
+	for _, w := range perWorker {
+		total += len(w)
+	}
+	out := make([]time.Duration, 0, total)
+	for _, w := range perWorker {
+		out = append(out, w...)
+	}
+	return out
+}
+
 func fmtSec(d time.Duration) string {
 	return fmt.Sprintf("%.3f s", d.Seconds())
 }
 
+func fmtLat(d time.Duration) string {
+	return d.String()
+}
+
+func fmtBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for n/div >= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 func printTable(title string, results []Result) {
 	fmt.Println()
 	fmt.Println(title)
@@ -102,6 +355,274 @@ func printTable(title string, results []Result) {
 	}
 }
 
+func printLatencyStats(title string, results []Result) {
+	fmt.Println()
+	fmt.Println(title)
+	fmt.Println("-----------------------------------")
+	fmt.Printf("%-12s %10s %10s %10s %10s %10s %10s %10s %10s %10s\n",
+		"Model", "mean", "p50", "p90", "p95", "p99", "min", "max", "stddev", "ops/sec")
+	fmt.Println("--------------------------------------------------------------------------------------------------------")
+	for _, r := range results {
+		fmt.Printf("%-12s %10s %10s %10s %10s %10s %10s %10s %10s %10.1f\n",
+			r.Model,
+			fmtLat(r.OpMean()),
+			fmtLat(r.OpPercentile(50)),
+			fmtLat(r.OpPercentile(90)),
+			fmtLat(r.OpPercentile(95)),
+			fmtLat(r.OpPercentile(99)),
+			fmtLat(r.OpMin()),
+			fmtLat(r.OpMax()),
+			fmtLat(r.OpStdDev()),
+			r.Throughput(),
+		)
+	}
+}
+
+// printResourceStats reports median/peak process resource usage sampled at ~100ms intervals
+// while each measured run was in flight. For the "processes" model, rss/osthreads/fds are
+// aggregated across the benchmark process and every child it had alive at sample time.
+func printResourceStats(title string, results []Result) {
+	fmt.Println()
+	fmt.Println(title)
+	fmt.Println("-----------------------------------")
+	fmt.Printf("%-12s %8s %8s %10s %10s %8s %8s %8s %8s %6s %6s\n",
+		"Model", "cpu%", "cpu%pk", "rss", "rss-pk", "goro", "goro-pk", "osthr", "osthr-pk", "fds", "fds-pk")
+	fmt.Println("--------------------------------------------------------------------------------------------------------")
+	for _, r := range results {
+		if len(r.Resources) == 0 {
+			// No background sample landed for this model (a very short measured run can be
+			// collected before the sampler goroutine takes its first, immediate sample, and a
+			// model stopped before any measured repeat has none at all). Say so explicitly rather than printing a row of zeros indistinguishable from a real
+			// (impossible) zero goroutine/thread/fd count.
+			fmt.Printf("%-12s %8s %8s %10s %10s %8s %8s %8s %8s %6s %6s\n",
+				r.Model, "n/a", "n/a", "n/a", "n/a", "n/a", "n/a", "n/a", "n/a", "n/a", "n/a")
+			continue
+		}
+		cpuSamples := r.cpuPercentSamples()
+		rssSamples := r.rssSamples()
+		goroSamples := r.goroutineSamples()
+		osthrSamples := r.osThreadSamples()
+		fdSamples := r.fdSamples()
+		fmt.Printf("%-12s %7.1f%% %7.1f%% %10s %10s %8d %8d %8d %8d %6d %6d\n",
+			r.Model,
+			medianFloat(cpuSamples), peakFloat(cpuSamples),
+			fmtBytes(medianUint64(rssSamples)), fmtBytes(peakUint64(rssSamples)),
+			medianInt(goroSamples), peakInt(goroSamples),
+			medianInt(osthrSamples), peakInt(osthrSamples),
+			medianInt(fdSamples), peakInt(fdSamples),
+		)
+	}
+}
+
+// histBucketBounds are log-scale latency boundaries spanning 10µs..10s, two buckets per decade.
+var histBucketBounds = buildLogBuckets(10*time.Microsecond, 10*time.Second, 2)
+
+func buildLogBuckets(lo, hi time.Duration, perDecade int) []time.Duration {
+	factor := math.Pow(10, 1.0/float64(perDecade))
+	var bounds []time.Duration
+	for b := float64(lo); b <= float64(hi)*1.0001; b *= factor {
+		bounds = append(bounds, time.Duration(b))
+	}
+	return bounds
+}
+
+type Histogram struct {
+	Bounds    []time.Duration
+	Counts    []int
+	Underflow int
+	Overflow  int
+}
+
+func buildHistogram(xs []time.Duration) Histogram {
+	h := Histogram{Bounds: histBucketBounds, Counts: make([]int, len(histBucketBounds)-1)}
+	for _, x := range xs {
+		switch {
+		case x < h.Bounds[0]:
+			h.Underflow++
+		case x >= h.Bounds[len(h.Bounds)-1]:
+			h.Overflow++
+		default:
+			for i := 0; i < len(h.Counts); i++ {
+				if x >= h.Bounds[i] && x < h.Bounds[i+1] {
+					h.Counts[i]++
+					break
+				}
+			}
+		}
+	}
+	return h
+}
+
+func printHistograms(title string, results []Result) {
+	fmt.Println()
+	fmt.Println(title)
+	fmt.Println("-----------------------------------")
+	for _, r := range results {
+		fmt.Printf("\n%s:\n", r.Model)
+		h := buildHistogram(r.Ops)
+
+		maxCount := h.Underflow
+		if h.Overflow > maxCount {
+			maxCount = h.Overflow
+		}
+		for _, c := range h.Counts {
+			if c > maxCount {
+				maxCount = c
+			}
+		}
+
+		const barWidth = 40
+		printBar := func(label string, count int) {
+			barLen := 0
+			if maxCount > 0 {
+				barLen = count * barWidth / maxCount
+			}
+			fmt.Printf("  %16s | %-40s %d\n", label, strings.Repeat("#", barLen), count)
+		}
+
+		if h.Underflow > 0 {
+			printBar("< "+h.Bounds[0].String(), h.Underflow)
+		}
+		for i, c := range h.Counts {
+			printBar(h.Bounds[i].String()+"-"+h.Bounds[i+1].String(), c)
+		}
+		if h.Overflow > 0 {
+			printBar(">= "+h.Bounds[len(h.Bounds)-1].String(), h.Overflow)
+		}
+	}
+}
+
+// resourceSampleInterval is how often the background sampler snapshots process resource usage
+// during a measured run.
+const resourceSampleInterval = 100 * time.Millisecond
+
+// ResourceSample is one point-in-time reading taken by the background resource sampler.
+type ResourceSample struct {
+	CPUPercent float64
+	RSSBytes   uint64
+	Goroutines int
+	OSThreads  int
+	FDs        int
+}
+
+// procStats is what readProcStats can determine about a single OS process; platform-specific
+// implementations live in procstats_linux.go, procstats_darwin.go, and procstats_windows.go.
+type procStats struct {
+	CPUTime    time.Duration
+	RSSBytes   uint64
+	NumThreads int
+	NumFDs     int
+}
+
+// childRegistry tracks the PIDs of currently-live child processes spawned by the "processes"
+// model, so the resource sampler can aggregate them alongside the benchmark process itself.
+type childRegistry struct {
+	mu   sync.Mutex
+	pids map[int]struct{}
+}
+
+func newChildRegistry() *childRegistry {
+	return &childRegistry{pids: make(map[int]struct{})}
+}
+
+func (r *childRegistry) add(pid int) {
+	r.mu.Lock()
+	r.pids[pid] = struct{}{}
+	r.mu.Unlock()
+}
+
+func (r *childRegistry) remove(pid int) {
+	r.mu.Lock()
+	delete(r.pids, pid)
+	r.mu.Unlock()
+}
+
+func (r *childRegistry) snapshot() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pids := make([]int, 0, len(r.pids))
+	for pid := range r.pids {
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// aggregateProcStats combines the benchmark process's own stats with every child PID currently
+// registered in reg (nil for the threads/goroutines models, which have no child processes).
+// When readProcStats can't determine the process's real OS thread count (e.g. unsupported
+// platform), it falls back to the Go runtime's own thread-creation counter.
+func aggregateProcStats(selfPid int, reg *childRegistry) (cpuTotal time.Duration, rssBytes uint64, fds, osThreads int) {
+	if self, err := readProcStats(selfPid); err == nil {
+		cpuTotal += self.CPUTime
+		rssBytes += self.RSSBytes
+		fds += self.NumFDs
+		osThreads += self.NumThreads
+	}
+	if osThreads == 0 {
+		osThreads += pprof.Lookup("threadcreate").Count()
+	}
+
+	if reg != nil {
+		for _, pid := range reg.snapshot() {
+			child, err := readProcStats(pid)
+			if err != nil {
+				continue
+			}
+			cpuTotal += child.CPUTime
+			rssBytes += child.RSSBytes
+			fds += child.NumFDs
+			osThreads += child.NumThreads
+		}
+	}
+	return
+}
+
+// sampleResources records a ResourceSample roughly every interval until ctx is done, appending
+// each to out under mu. CPUPercent is derived from the CPU-time delta between consecutive
+// samples divided by the elapsed wall time, so the first sample always reports 0%.
+func sampleResources(ctx context.Context, reg *childRegistry, interval time.Duration, mu *sync.Mutex, out *[]ResourceSample) {
+	selfPid := os.Getpid()
+	var prevCPU time.Duration
+	var prevTime time.Time
+
+	record := func(now time.Time) {
+		cpuTotal, rssBytes, fds, osThreads := aggregateProcStats(selfPid, reg)
+		sample := ResourceSample{
+			RSSBytes:   rssBytes,
+			Goroutines: runtime.NumGoroutine(),
+			OSThreads:  osThreads,
+			FDs:        fds,
+		}
+		if !prevTime.IsZero() {
+			if elapsed := now.Sub(prevTime); elapsed > 0 {
+				sample.CPUPercent = float64(cpuTotal-prevCPU) / float64(elapsed) * 100
+			}
+		}
+		prevCPU, prevTime = cpuTotal, now
+
+		mu.Lock()
+		*out = append(*out, sample)
+		mu.Unlock()
+	}
+
+	// Take one sample immediately rather than waiting for the first tick: a measured run shorter
+	// than interval (e.g. a handful of fast goroutine/thread ops) would otherwise end and get
+	// torn down before the ticker ever fired, leaving it with zero samples instead of one.
+	record(time.Now())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			record(now)
+		}
+	}
+}
+
 func cpuWork(units int) uint32 {
 	var acc uint32 = 0
 	for i := 0; i < units; i++ {
@@ -110,100 +631,253 @@ func cpuWork(units int) uint32 {
 	return acc
 }
 
-func cpuGoroutines(cfg Config) {
-	sem := make(chan struct{}, cfg.Concurrency)
+// cpuGoroutines dispatches one goroutine per task, bounding concurrency to cfg.Concurrency via a
+// channel of slot indices rather than empty-struct tokens: the slot a dispatch receives is also
+// where its goroutine appends its own latency, so concurrent tasks never touch the same slice and
+// no mutex is needed on the per-op hot path (mergeLatencies flattens the per-slot slices after).
+func cpuGoroutines(ctx context.Context, cfg Config) []time.Duration {
+	sem := make(chan int, cfg.Concurrency)
+	for i := 0; i < cfg.Concurrency; i++ {
+		sem <- i
+	}
 	var wg sync.WaitGroup
 	var sum uint32
+	perSlot := make([][]time.Duration, cfg.Concurrency)
 
-	for i := 0; i < cfg.Tasks; i++ {
+dispatch:
+	for i := 0; cfg.RunTime > 0 || i < cfg.Tasks; i++ {
+		var slot int
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case slot = <-sem:
+		}
 		wg.Add(1)
-		sem <- struct{}{}
-		go func() {
+		go func(slot int) {
 			defer wg.Done()
+			t0 := time.Now()
 			sum ^= cpuWork(cfg.CpuUnits)
-			<-sem
-		}()
+			perSlot[slot] = append(perSlot[slot], time.Since(t0))
+			sem <- slot
+		}(slot)
 	}
-	wg.Wait()
+	waitForDrain(ctx, &wg)
 	_ = sum
+	return mergeLatencies(perSlot)
 }
 
-func cpuThreads(cfg Config) {
-	jobs := make(chan struct{}, cfg.Tasks)
-	for i := 0; i < cfg.Tasks; i++ {
-		jobs <- struct{}{}
+func cpuThreads(ctx context.Context, cfg Config) []time.Duration {
+	workers := cfg.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var jobs chan struct{}
+	if cfg.RunTime == 0 {
+		jobs = make(chan struct{}, cfg.Tasks)
+		for i := 0; i < cfg.Tasks; i++ {
+			jobs <- struct{}{}
+		}
+		close(jobs)
 	}
-	close(jobs)
 
 	var wg sync.WaitGroup
 	var sum uint32
 	var mu sync.Mutex
-
-	workers := cfg.Concurrency
-	if workers < 1 {
-		workers = 1
-	}
+	perWorker := make([][]time.Duration, workers)
 
 	wg.Add(workers)
 	for w := 0; w < workers; w++ {
-		go func() {
+		go func(w int) {
 			runtime.LockOSThread()
 			defer runtime.UnlockOSThread()
 			defer wg.Done()
 
 			var local uint32
-			for range jobs {
+			var latencies []time.Duration
+		worker:
+			for {
+				if cfg.RunTime == 0 {
+					select {
+					case <-ctx.Done():
+						break worker
+					case _, ok := <-jobs:
+						if !ok {
+							break worker
+						}
+					}
+				} else if ctx.Err() != nil {
+					break worker
+				}
+				t0 := time.Now()
 				local ^= cpuWork(cfg.CpuUnits)
+				latencies = append(latencies, time.Since(t0))
 			}
+			perWorker[w] = latencies
+
 			mu.Lock()
 			sum ^= local
 			mu.Unlock()
-		}()
+		}(w)
 	}
-	wg.Wait()
+	waitForDrain(ctx, &wg)
 	_ = sum
+	return mergeLatencies(perWorker)
 }
 
-func cpuProcesses(cfg Config) {
-	sem := make(chan struct{}, cfg.Concurrency)
+// cpuProcesses dispatches one subprocess per task, bounding concurrency via a channel of slot
+// indices (see cpuGoroutines) so each dispatch appends its latency to its own slot's slice
+// instead of contending on a shared mutex.
+func cpuProcesses(ctx context.Context, cfg Config, reg *childRegistry) []time.Duration {
+	sem := make(chan int, cfg.Concurrency)
+	for i := 0; i < cfg.Concurrency; i++ {
+		sem <- i
+	}
 	var wg sync.WaitGroup
+	perSlot := make([][]time.Duration, cfg.Concurrency)
 
-	for i := 0; i < cfg.Tasks; i++ {
+dispatch:
+	for i := 0; cfg.RunTime > 0 || i < cfg.Tasks; i++ {
+		var slot int
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case slot = <-sem:
+		}
 		wg.Add(1)
-		sem <- struct{}{}
-		go func() {
+		go func(slot int) {
 			defer wg.Done()
-			cmd := exec.Command(os.Args[0], "cpu-child", strconv.Itoa(cfg.CpuUnits))
-			_ = cmd.Run()
-			<-sem
-		}()
+			t0 := time.Now()
+			// CommandContext, not Command: cpu-child doesn't install its own signal handling, so a
+			// SIGTERM to just this process wouldn't reach it. If ctx is cancelled mid-Wait, the
+			// context kills the child directly instead of leaving it to run past waitForDrain's
+			// shutdownGrace as an orphan.
+			cmd := exec.CommandContext(ctx, os.Args[0], "cpu-child", strconv.Itoa(cfg.CpuUnits))
+			if err := cmd.Start(); err == nil {
+				reg.add(cmd.Process.Pid)
+				_ = cmd.Wait()
+				reg.remove(cmd.Process.Pid)
+			}
+			perSlot[slot] = append(perSlot[slot], time.Since(t0))
+			sem <- slot
+		}(slot)
 	}
-	wg.Wait()
+	waitForDrain(ctx, &wg)
+	return mergeLatencies(perSlot)
 }
 
 func cpuChild(units int) {
 	_ = cpuWork(units)
 }
 
-type EchoServer struct {
-	ln   net.Listener
-	addr string
-	done chan struct{}
+// Workload is one pluggable I/O protocol the harness can drive. Start runs once per benchmark
+// process to provision a target (e.g. a local echo server) and is responsible for also calling
+// Configure; Configure alone is used by io-child subprocesses, which talk to a target a sibling
+// process already provisioned. Do performs a single operation and reports its latency. NewConn
+// opens a connection usable across many ops, for --io-mode=keepalive|pipeline; workloads that
+// can't support that (http, blob) return errIOModeUnsupported and the caller falls back to
+// dial-per-op.
+type Workload interface {
+	Start(cfg Config) (target string, teardown func(), err error)
+	Configure(cfg Config)
+	Do(ctx context.Context, target string, timeout time.Duration) (time.Duration, error)
+	NewConn(ctx context.Context, target string, timeout time.Duration) (WorkloadConn, error)
 }
 
-func startEchoServer(cfg Config) (*EchoServer, error) {
-	ln, err := net.Listen("tcp", "127.0.0.1:0")
-	if err != nil {
-		return nil, err
+// WorkloadConn is a connection opened once by Workload.NewConn and reused across many ops.
+type WorkloadConn interface {
+	// Do issues one op over the connection.
+	Do(ctx context.Context, timeout time.Duration) (time.Duration, error)
+	// Pipeline issues depth outstanding writes before reading any of their responses back,
+	// returning each op's latency from when it was written to when its response arrived. Because
+	// every op writes the same fixed-size payload and the underlying transport preserves
+	// ordering, the Nth response read back always correlates with the Nth write — no explicit
+	// request IDs are needed. That ordering guarantee holds for tcp-echo/unix (stream sockets);
+	// implementers over an unordered transport (e.g. UDP) must not expose pipelining this way —
+	// see the --workload=udp + --io-mode=pipeline rejection in main.
+	Pipeline(ctx context.Context, depth int, timeout time.Duration) ([]time.Duration, error)
+	Close() error
+}
+
+// errIOModeUnsupported is returned by Workload.NewConn for workloads that have no notion of a
+// reusable connection (http relies on its own client's keep-alive pool; blob's freshly randomized
+// per-op payload doesn't fit the fixed-payload echo/correlate shape). Callers downgrade to
+// dial-per-op rather than treating it as a hard error.
+var errIOModeUnsupported = errors.New("workload does not support --io-mode=keepalive|pipeline")
+
+// echoConn drives the write-then-read-equal-size-echo protocol shared by tcp-echo, unix, and udp
+// over an already-open connection.
+type echoConn struct {
+	conn    net.Conn
+	payload []byte
+}
+
+func (c *echoConn) Close() error { return c.conn.Close() }
+
+func (c *echoConn) Do(ctx context.Context, timeout time.Duration) (time.Duration, error) {
+	t0 := time.Now()
+	_ = c.conn.SetDeadline(time.Now().Add(timeout))
+	if _, err := c.conn.Write(c.payload); err != nil {
+		return time.Since(t0), err
+	}
+	buf := make([]byte, len(c.payload))
+	if _, err := io.ReadFull(c.conn, buf); err != nil {
+		return time.Since(t0), err
+	}
+	return time.Since(t0), nil
+}
+
+func (c *echoConn) Pipeline(ctx context.Context, depth int, timeout time.Duration) ([]time.Duration, error) {
+	if depth < 1 {
+		depth = 1
 	}
-	s := &EchoServer{
-		ln:   ln,
-		addr: ln.Addr().String(),
-		done: make(chan struct{}),
+	_ = c.conn.SetDeadline(time.Now().Add(timeout))
+
+	sent := make([]time.Time, depth)
+	for i := 0; i < depth; i++ {
+		sent[i] = time.Now()
+		if _, err := c.conn.Write(c.payload); err != nil {
+			return nil, err
+		}
+	}
+
+	latencies := make([]time.Duration, 0, depth)
+	buf := make([]byte, len(c.payload))
+	for i := 0; i < depth; i++ {
+		if _, err := io.ReadFull(c.conn, buf); err != nil {
+			return latencies, err
+		}
+		latencies = append(latencies, time.Since(sent[i]))
+	}
+	return latencies, nil
+}
+
+var workloadFactories = map[string]func() Workload{
+	"tcp-echo": func() Workload { return &tcpEchoWorkload{} },
+	"unix":     func() Workload { return &unixEchoWorkload{} },
+	"udp":      func() Workload { return &udpEchoWorkload{} },
+	"http":     func() Workload { return &httpWorkload{} },
+	"blob":     func() Workload { return &blobWorkload{} },
+}
+
+func newWorkload(name string) (Workload, error) {
+	f, ok := workloadFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown --workload %q (want tcp-echo|http|unix|udp|blob)", name)
 	}
+	return f(), nil
+}
 
+// startStreamEchoServer listens on network/address and echoes back whatever each connection
+// sends, byte for byte, via handleConn. Used by the tcp-echo, unix, and blob workloads.
+func startStreamEchoServer(network, address string) (actualAddr string, teardown func(), err error) {
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return "", nil, err
+	}
+	done := make(chan struct{})
 	go func() {
-		defer close(s.done)
+		defer close(done)
 		for {
 			conn, err := ln.Accept()
 			if err != nil {
@@ -212,13 +886,11 @@ func startEchoServer(cfg Config) (*EchoServer, error) {
 			go handleConn(conn)
 		}
 	}()
-
-	return s, nil
-}
-
-func (s *EchoServer) Close() {
-	_ = s.ln.Close()
-	<-s.done
+	teardown = func() {
+		_ = ln.Close()
+		<-done
+	}
+	return ln.Addr().String(), teardown, nil
 }
 
 func handleConn(conn net.Conn) {
@@ -239,18 +911,25 @@ func handleConn(conn net.Conn) {
 	}
 }
 
-func ioOne(addr string, payload []byte, timeout time.Duration) error {
+// dialWriteReadEcho dials network/target, writes payload, and reads back an equal-sized echo,
+// returning the round-trip latency. ctx cancellation (run-time deadline or shutdown signal)
+// aborts an in-flight dial/write/read by closing the connection, rather than relying solely on
+// the per-call deadline.
+func dialWriteReadEcho(ctx context.Context, network, target string, payload []byte, timeout time.Duration) (time.Duration, error) {
+	t0 := time.Now()
+
 	d := net.Dialer{Timeout: timeout}
-	conn, err := d.Dial("tcp", addr)
+	conn, err := d.DialContext(ctx, network, target)
 	if err != nil {
-		return err
+		return time.Since(t0), err
 	}
 	defer conn.Close()
+	defer context.AfterFunc(ctx, func() { conn.Close() })()
 
 	_ = conn.SetDeadline(time.Now().Add(timeout))
 
 	if _, err := conn.Write(payload); err != nil {
-		return err
+		return time.Since(t0), err
 	}
 
 	want := len(payload)
@@ -262,92 +941,867 @@ func ioOne(addr string, payload []byte, timeout time.Duration) error {
 			got += n
 		}
 		if err != nil {
-			return err
+			return time.Since(t0), err
 		}
 	}
-	return nil
+	return time.Since(t0), nil
 }
 
-func ioGoroutines(cfg Config, addr string, payload []byte) {
-	sem := make(chan struct{}, cfg.Concurrency)
-	var wg sync.WaitGroup
-	timeout := time.Duration(cfg.TimeoutMs) * time.Millisecond
-
-	for i := 0; i < cfg.Tasks; i++ {
-		wg.Add(1)
-		sem <- struct{}{}
-		go func() {
-			defer wg.Done()
-			_ = ioOne(addr, payload, timeout)
-			<-sem
-		}()
-	}
-	wg.Wait()
+// tcpEchoWorkload is the original local loopback-TCP echo benchmark.
+type tcpEchoWorkload struct {
+	payload []byte
 }
 
-func ioThreads(cfg Config, addr string, payload []byte) {
-	jobs := make(chan struct{}, cfg.Tasks)
-	for i := 0; i < cfg.Tasks; i++ {
-		jobs <- struct{}{}
-	}
-	close(jobs)
+func (w *tcpEchoWorkload) Start(cfg Config) (string, func(), error) {
+	w.Configure(cfg)
+	return startStreamEchoServer("tcp", "127.0.0.1:0")
+}
 
-	timeout := time.Duration(cfg.TimeoutMs) * time.Millisecond
+func (w *tcpEchoWorkload) Configure(cfg Config) {
+	w.payload = make([]byte, cfg.PayloadSize)
+}
 
-	workers := cfg.Concurrency
-	if workers < 1 {
-		workers = 1
-	}
+func (w *tcpEchoWorkload) Do(ctx context.Context, target string, timeout time.Duration) (time.Duration, error) {
+	return dialWriteReadEcho(ctx, "tcp", target, w.payload, timeout)
+}
+
+func (w *tcpEchoWorkload) NewConn(ctx context.Context, target string, timeout time.Duration) (WorkloadConn, error) {
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return nil, err
+	}
+	return &echoConn{conn: conn, payload: w.payload}, nil
+}
+
+// unixEchoWorkload is the same echo protocol over a Unix domain socket.
+type unixEchoWorkload struct {
+	payload []byte
+}
+
+func (w *unixEchoWorkload) Start(cfg Config) (string, func(), error) {
+	w.Configure(cfg)
+	path := cfg.WorkloadTarget
+	if path == "" {
+		path = filepath.Join(os.TempDir(), fmt.Sprintf("bench-%d.sock", os.Getpid()))
+	}
+	_ = os.Remove(path)
+	_, teardown, err := startStreamEchoServer("unix", path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, func() {
+		teardown()
+		_ = os.Remove(path)
+	}, nil
+}
+
+func (w *unixEchoWorkload) Configure(cfg Config) {
+	w.payload = make([]byte, cfg.PayloadSize)
+}
+
+func (w *unixEchoWorkload) Do(ctx context.Context, target string, timeout time.Duration) (time.Duration, error) {
+	return dialWriteReadEcho(ctx, "unix", target, w.payload, timeout)
+}
+
+func (w *unixEchoWorkload) NewConn(ctx context.Context, target string, timeout time.Duration) (WorkloadConn, error) {
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, "unix", target)
+	if err != nil {
+		return nil, err
+	}
+	return &echoConn{conn: conn, payload: w.payload}, nil
+}
+
+// udpEchoWorkload bounces a single datagram per op off a local UDP echo listener.
+type udpEchoWorkload struct {
+	payload []byte
+}
+
+func (w *udpEchoWorkload) Start(cfg Config) (string, func(), error) {
+	w.Configure(cfg)
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 65536)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = pc.WriteTo(buf[:n], addr)
+		}
+	}()
+	teardown := func() {
+		_ = pc.Close()
+		<-done
+	}
+	return pc.LocalAddr().String(), teardown, nil
+}
+
+func (w *udpEchoWorkload) Configure(cfg Config) {
+	w.payload = make([]byte, cfg.PayloadSize)
+}
+
+func (w *udpEchoWorkload) Do(ctx context.Context, target string, timeout time.Duration) (time.Duration, error) {
+	t0 := time.Now()
+
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, "udp", target)
+	if err != nil {
+		return time.Since(t0), err
+	}
+	defer conn.Close()
+	defer context.AfterFunc(ctx, func() { conn.Close() })()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	if _, err := conn.Write(w.payload); err != nil {
+		return time.Since(t0), err
+	}
+	buf := make([]byte, len(w.payload))
+	if _, err := conn.Read(buf); err != nil {
+		return time.Since(t0), err
+	}
+	return time.Since(t0), nil
+}
+
+func (w *udpEchoWorkload) NewConn(ctx context.Context, target string, timeout time.Duration) (WorkloadConn, error) {
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, "udp", target)
+	if err != nil {
+		return nil, err
+	}
+	return &echoConn{conn: conn, payload: w.payload}, nil
+}
+
+// httpWorkload issues GET or PUT requests against an externally-provided URL, so it exposes
+// real protocol and server-processing overhead rather than a bare loopback round trip.
+type httpWorkload struct {
+	client  *http.Client
+	method  string
+	payload []byte
+}
+
+func (w *httpWorkload) Start(cfg Config) (string, func(), error) {
+	if cfg.WorkloadTarget == "" {
+		return "", nil, fmt.Errorf("--workload=http requires --workload-target=<url>")
+	}
+	w.Configure(cfg)
+	return cfg.WorkloadTarget, func() {}, nil
+}
+
+func (w *httpWorkload) Configure(cfg Config) {
+	w.client = &http.Client{}
+	w.method = strings.ToUpper(cfg.HTTPMethod)
+	if w.method == "" {
+		w.method = http.MethodGet
+	}
+	w.payload = make([]byte, cfg.PayloadSize)
+}
+
+func (w *httpWorkload) Do(ctx context.Context, target string, timeout time.Duration) (time.Duration, error) {
+	t0 := time.Now()
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var body io.Reader
+	if w.method == http.MethodPut {
+		body = bytes.NewReader(w.payload)
+	}
+	req, err := http.NewRequestWithContext(reqCtx, w.method, target, body)
+	if err != nil {
+		return time.Since(t0), err
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return time.Since(t0), err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return time.Since(t0), err
+}
+
+// NewConn reports errIOModeUnsupported: w.client already pools and reuses connections via its
+// default transport, so there's no separate persistent-connection mode to opt into.
+func (w *httpWorkload) NewConn(ctx context.Context, target string, timeout time.Duration) (WorkloadConn, error) {
+	return nil, errIOModeUnsupported
+}
+
+// blobWorkload streams a large random payload per op and verifies the echoed bytes match,
+// exposing bandwidth rather than round-trip cost.
+type blobWorkload struct {
+	sizeBytes int
+}
+
+func (w *blobWorkload) Start(cfg Config) (string, func(), error) {
+	w.Configure(cfg)
+	return startStreamEchoServer("tcp", "127.0.0.1:0")
+}
+
+func (w *blobWorkload) Configure(cfg Config) {
+	w.sizeBytes = cfg.BlobSizeMiB * 1024 * 1024
+}
+
+func (w *blobWorkload) Do(ctx context.Context, target string, timeout time.Duration) (time.Duration, error) {
+	t0 := time.Now()
+
+	payload := make([]byte, w.sizeBytes)
+	if _, err := cryptorand.Read(payload); err != nil {
+		return time.Since(t0), err
+	}
+
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return time.Since(t0), err
+	}
+	defer conn.Close()
+	defer context.AfterFunc(ctx, func() { conn.Close() })()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	writeErr := make(chan error, 1)
+	go func() {
+		_, err := conn.Write(payload)
+		writeErr <- err
+	}()
+
+	got := make([]byte, len(payload))
+	n, readErr := io.ReadFull(conn, got)
+	if err := <-writeErr; err != nil && readErr == nil {
+		readErr = err
+	}
+	if readErr != nil {
+		return time.Since(t0), readErr
+	}
+	if n != len(payload) || !bytes.Equal(got, payload) {
+		return time.Since(t0), fmt.Errorf("blob readback mismatch: got %d of %d bytes", n, len(payload))
+	}
+	return time.Since(t0), nil
+}
+
+// NewConn reports errIOModeUnsupported: each op's payload is freshly randomized and verified
+// against its own echo, which doesn't fit the fixed-payload positional correlation WorkloadConn
+// assumes.
+func (w *blobWorkload) NewConn(ctx context.Context, target string, timeout time.Duration) (WorkloadConn, error) {
+	return nil, errIOModeUnsupported
+}
+
+// ioPersistentModeEnabled reports whether cfg asked for a per-worker reused connection rather
+// than dialing fresh on every op.
+func ioPersistentModeEnabled(cfg Config) bool {
+	return cfg.IOMode == "keepalive" || cfg.IOMode == "pipeline"
+}
+
+func ioGoroutines(ctx context.Context, cfg Config, wl Workload, target string) []time.Duration {
+	timeout := time.Duration(cfg.TimeoutMs) * time.Millisecond
+	if ioPersistentModeEnabled(cfg) {
+		return ioGoroutinesPersistent(ctx, cfg, wl, target, timeout)
+	}
+	return ioGoroutinesDialPerOp(ctx, cfg, wl, target, timeout)
+}
+
+// pooledConn pairs a pooled connection with the slot index its borrower should record latency
+// into, so concurrent borrowers never touch the same slice (see cpuGoroutines).
+type pooledConn struct {
+	conn WorkloadConn
+	slot int
+}
+
+// ioGoroutinesPersistent is the --io-mode=keepalive|pipeline goroutines model. The one-op-per-
+// goroutine dispatch pattern has no natural "worker" to keep a connection alive across ops, so a
+// pool of cfg.Concurrency connections doubles as the dispatch semaphore: a dispatched goroutine
+// borrows a connection, uses it, and returns it to the pool when done. Falls back to dial-per-op
+// if wl doesn't support a persistent connection (http, blob).
+func ioGoroutinesPersistent(ctx context.Context, cfg Config, wl Workload, target string, timeout time.Duration) []time.Duration {
+	pool := make(chan pooledConn, cfg.Concurrency)
+	for i := 0; i < cfg.Concurrency; i++ {
+		conn, err := wl.NewConn(ctx, target, timeout)
+		if err != nil {
+			for j := 0; j < i; j++ {
+				(<-pool).conn.Close()
+			}
+			return ioGoroutinesDialPerOp(ctx, cfg, wl, target, timeout)
+		}
+		pool <- pooledConn{conn: conn, slot: i}
+	}
+
+	var wg sync.WaitGroup
+	perSlot := make([][]time.Duration, cfg.Concurrency)
+
+dispatch:
+	for i := 0; cfg.RunTime > 0 || i < cfg.Tasks; i++ {
+		var pc pooledConn
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case pc = <-pool:
+		}
+		wg.Add(1)
+		go func(pc pooledConn) {
+			defer wg.Done()
+
+			var err error
+			var d time.Duration
+			var batch []time.Duration
+			if cfg.IOMode == "pipeline" {
+				batch, err = pc.conn.Pipeline(ctx, cfg.PipelineDepth, timeout)
+			} else {
+				d, err = pc.conn.Do(ctx, timeout)
+			}
+
+			perSlot[pc.slot] = append(perSlot[pc.slot], batch...)
+			if cfg.IOMode != "pipeline" {
+				perSlot[pc.slot] = append(perSlot[pc.slot], d)
+			}
+
+			if err != nil {
+				// The connection is now in an unknown state (e.g. a partial pipelined write left
+				// the stream out of sync); don't let a later op reuse it. Try to replace it so the
+				// pool's concurrency level doesn't quietly shrink; drop the slot if that also fails.
+				pc.conn.Close()
+				if fresh, rerr := wl.NewConn(ctx, target, timeout); rerr == nil {
+					pool <- pooledConn{conn: fresh, slot: pc.slot}
+				}
+			} else {
+				// Only put the slot back on the pool once its append above has landed: otherwise a
+				// new dispatch could pop the same slot and append to perSlot[pc.slot] concurrently
+				// with this goroutine, racing on the same slice header.
+				pool <- pc
+			}
+		}(pc)
+	}
+	waitForDrain(ctx, &wg)
+
+	// Best-effort close of whatever connections are currently idle in the pool. The channel is
+	// never closed: a worker goroutine still in flight past waitForDrain's shutdownGrace bound
+	// may try to return (or replace) its connection after this function has returned, and sending
+	// on a closed channel would panic the whole benchmark.
+drainPool:
+	for {
+		select {
+		case pc := <-pool:
+			pc.conn.Close()
+		default:
+			break drainPool
+		}
+	}
+	return mergeLatencies(perSlot)
+}
+
+// ioGoroutinesDialPerOp is the original dial-per-op goroutines model, also used as the fallback
+// when a workload doesn't implement NewConn. It dispatches one goroutine per task, bounding
+// concurrency via a channel of slot indices (see cpuGoroutines) so each dispatch appends its
+// latency to its own slot's slice instead of contending on a shared mutex.
+func ioGoroutinesDialPerOp(ctx context.Context, cfg Config, wl Workload, target string, timeout time.Duration) []time.Duration {
+	sem := make(chan int, cfg.Concurrency)
+	for i := 0; i < cfg.Concurrency; i++ {
+		sem <- i
+	}
+	var wg sync.WaitGroup
+	perSlot := make([][]time.Duration, cfg.Concurrency)
+
+dispatch:
+	for i := 0; cfg.RunTime > 0 || i < cfg.Tasks; i++ {
+		var slot int
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case slot = <-sem:
+		}
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			d, _ := wl.Do(ctx, target, timeout)
+			perSlot[slot] = append(perSlot[slot], d)
+			sem <- slot
+		}(slot)
+	}
+	waitForDrain(ctx, &wg)
+	return mergeLatencies(perSlot)
+}
+
+func ioThreads(ctx context.Context, cfg Config, wl Workload, target string) []time.Duration {
+	timeout := time.Duration(cfg.TimeoutMs) * time.Millisecond
+
+	workers := cfg.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var jobs chan struct{}
+	if cfg.RunTime == 0 {
+		jobs = make(chan struct{}, cfg.Tasks)
+		for i := 0; i < cfg.Tasks; i++ {
+			jobs <- struct{}{}
+		}
+		close(jobs)
+	}
+
+	perWorker := make([][]time.Duration, workers)
 
 	var wg sync.WaitGroup
 	wg.Add(workers)
 	for w := 0; w < workers; w++ {
-		go func() {
+		go func(w int) {
 			runtime.LockOSThread()
 			defer runtime.UnlockOSThread()
 			defer wg.Done()
-			for range jobs {
-				_ = ioOne(addr, payload, timeout)
+
+			mode := cfg.IOMode
+			var conn WorkloadConn
+			if ioPersistentModeEnabled(cfg) {
+				c, err := wl.NewConn(ctx, target, timeout)
+				if err != nil {
+					mode = "dial-per-op"
+				} else {
+					conn = c
+					defer func() { conn.Close() }()
+				}
 			}
-		}()
+
+			// reconnect replaces conn after an op error leaves it in an unknown state (e.g. a
+			// partial pipelined write desyncs the stream). If a fresh connection can't be opened
+			// either, the target is presumably down, so the worker falls back to dial-per-op
+			// rather than spinning on a broken connection for the rest of the run.
+			reconnect := func() {
+				conn.Close()
+				if fresh, err := wl.NewConn(ctx, target, timeout); err == nil {
+					conn = fresh
+				} else {
+					mode = "dial-per-op"
+				}
+			}
+
+			var latencies []time.Duration
+		worker:
+			for {
+				if cfg.RunTime == 0 {
+					select {
+					case <-ctx.Done():
+						break worker
+					case _, ok := <-jobs:
+						if !ok {
+							break worker
+						}
+					}
+				} else if ctx.Err() != nil {
+					break worker
+				}
+
+				switch mode {
+				case "pipeline":
+					batch, err := conn.Pipeline(ctx, cfg.PipelineDepth, timeout)
+					latencies = append(latencies, batch...)
+					if err != nil {
+						reconnect()
+					}
+				case "keepalive":
+					d, err := conn.Do(ctx, timeout)
+					latencies = append(latencies, d)
+					if err != nil {
+						reconnect()
+					}
+				default:
+					d, _ := wl.Do(ctx, target, timeout)
+					latencies = append(latencies, d)
+				}
+			}
+			perWorker[w] = latencies
+		}(w)
 	}
-	wg.Wait()
+	waitForDrain(ctx, &wg)
+	return mergeLatencies(perWorker)
 }
 
-func ioProcesses(cfg Config, addr string) {
+// ioProcesses always dials per op, ignoring cfg.IOMode: each op is its own freshly spawned
+// io-child process, so there's no worker to keep a connection alive between ops.
+func ioProcesses(ctx context.Context, cfg Config, target string, reg *childRegistry) []time.Duration {
 	sem := make(chan struct{}, cfg.Concurrency)
 	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var latencies []time.Duration
 
-	for i := 0; i < cfg.Tasks; i++ {
+dispatch:
+	for i := 0; cfg.RunTime > 0 || i < cfg.Tasks; i++ {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case sem <- struct{}{}:
+		}
 		wg.Add(1)
-		sem <- struct{}{}
 		go func() {
 			defer wg.Done()
-			cmd := exec.Command(os.Args[0], "io-child", addr, strconv.Itoa(cfg.PayloadSize), strconv.Itoa(cfg.TimeoutMs))
-			_ = cmd.Run()
+			t0 := time.Now()
+			// CommandContext, not Command: see cpuProcesses for why -- io-child needs ctx to kill
+			// it directly rather than relying on a SIGTERM that never reaches it.
+			cmd := exec.CommandContext(ctx, os.Args[0], "io-child",
+				cfg.Workload, target,
+				strconv.Itoa(cfg.PayloadSize), strconv.Itoa(cfg.TimeoutMs),
+				cfg.HTTPMethod, strconv.Itoa(cfg.BlobSizeMiB))
+			if err := cmd.Start(); err == nil {
+				reg.add(cmd.Process.Pid)
+				_ = cmd.Wait()
+				reg.remove(cmd.Process.Pid)
+			}
+			d := time.Since(t0)
+			mu.Lock()
+			latencies = append(latencies, d)
+			mu.Unlock()
 			<-sem
 		}()
 	}
-	wg.Wait()
+	waitForDrain(ctx, &wg)
+	return latencies
 }
 
-func ioChild(addr string, payloadSize int, timeoutMs int) {
-	payload := make([]byte, payloadSize)
+func ioChild(workloadName, target string, payloadSize, timeoutMs int, httpMethod string, blobSizeMiB int) {
+	wl, err := newWorkload(workloadName)
+	if err != nil {
+		os.Exit(1)
+	}
+	wl.Configure(Config{
+		PayloadSize: payloadSize,
+		HTTPMethod:  httpMethod,
+		BlobSizeMiB: blobSizeMiB,
+	})
 	timeout := time.Duration(timeoutMs) * time.Millisecond
-	_ = ioOne(addr, payload, timeout)
+	_, _ = wl.Do(context.Background(), target, timeout)
 }
 
-func runRepeated(cfg Config, label string, fn func()) Result {
-	for i := 0; i < cfg.Warmup; i++ {
-		fn()
+// runRepeated runs fn for cfg.Warmup + cfg.Repeats measured iterations. When cfg.RunTime is set,
+// it bounds the whole call (warmup included) with a single deadline derived from ctx -- a soak
+// test's "keep issuing work for 60s" is one 60s budget, not 60s per repeat -- so --repeats then
+// describes how many measured samples to collect inside that window rather than a multiplier on
+// it. ctx itself (canceled by signal) stops the whole benchmark early either way, returning
+// whatever repeats and op latencies were already collected. A background sampler (reg may be nil)
+// records process resource usage at ~100ms intervals for the duration of each measured iteration.
+func runRepeated(ctx context.Context, cfg Config, label string, reg *childRegistry, fn func(context.Context) []time.Duration) Result {
+	runCtx := ctx
+	if cfg.RunTime > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, cfg.RunTime)
+		defer cancel()
+	}
+
+	for i := 0; i < cfg.Warmup && runCtx.Err() == nil; i++ {
+		fn(runCtx)
 	}
 	runs := make([]time.Duration, 0, cfg.Repeats)
+	var ops []time.Duration
+	var resources []ResourceSample
 	for i := 0; i < cfg.Repeats; i++ {
+		if runCtx.Err() != nil {
+			break
+		}
+
+		sampleCtx, stopSampling := context.WithCancel(context.Background())
+		var sampleMu sync.Mutex
+		var samples []ResourceSample
+		go sampleResources(sampleCtx, reg, resourceSampleInterval, &sampleMu, &samples)
+
 		t0 := time.Now()
-		fn()
+		opLatencies := fn(runCtx)
 		runs = append(runs, time.Since(t0))
+		ops = append(ops, opLatencies...)
+
+		stopSampling()
+		sampleMu.Lock()
+		resources = append(resources, samples...)
+		sampleMu.Unlock()
+	}
+	return Result{Model: label, Runs: runs, Ops: ops, Resources: resources}
+}
+
+// statSummary is the computed latency distribution for one model, persisted in --output=json/csv
+// reports so later runs can be diffed without re-deriving percentiles from raw samples.
+type statSummary struct {
+	MeanSeconds   float64 `json:"mean_seconds"`
+	P50Seconds    float64 `json:"p50_seconds"`
+	P90Seconds    float64 `json:"p90_seconds"`
+	P95Seconds    float64 `json:"p95_seconds"`
+	P99Seconds    float64 `json:"p99_seconds"`
+	MinSeconds    float64 `json:"min_seconds"`
+	MaxSeconds    float64 `json:"max_seconds"`
+	StdDevSeconds float64 `json:"stddev_seconds"`
+	ThroughputOps float64 `json:"throughput_ops_per_sec"`
+}
+
+// modelReport is one model's (threads/processes/goroutines) results in a persisted report.
+// RunSeconds holds the per-measured-run wall time, the sample --compare runs its significance
+// test against.
+type modelReport struct {
+	Model      string      `json:"model"`
+	RunSeconds []float64   `json:"run_seconds"`
+	OpLatency  statSummary `json:"op_latency"`
+}
+
+// runReport is the machine-readable record written by --output=json/csv: enough to identify what
+// produced it (config, git SHA, host, GOMAXPROCS) and to diff against a later run via --compare.
+type runReport struct {
+	GeneratedAt string        `json:"generated_at"`
+	GitSHA      string        `json:"git_sha,omitempty"`
+	Hostname    string        `json:"hostname"`
+	GOMAXPROCS  int           `json:"gomaxprocs"`
+	NumCPU      int           `json:"num_cpu"`
+	Config      Config        `json:"config"`
+	CPUBound    []modelReport `json:"cpu_bound"`
+	IOBound     []modelReport `json:"io_bound,omitempty"`
+}
+
+func buildModelReport(r Result) modelReport {
+	runSeconds := make([]float64, len(r.Runs))
+	for i, d := range r.Runs {
+		runSeconds[i] = d.Seconds()
+	}
+	return modelReport{
+		Model:      r.Model,
+		RunSeconds: runSeconds,
+		OpLatency: statSummary{
+			MeanSeconds:   r.OpMean().Seconds(),
+			P50Seconds:    r.OpPercentile(50).Seconds(),
+			P90Seconds:    r.OpPercentile(90).Seconds(),
+			P95Seconds:    r.OpPercentile(95).Seconds(),
+			P99Seconds:    r.OpPercentile(99).Seconds(),
+			MinSeconds:    r.OpMin().Seconds(),
+			MaxSeconds:    r.OpMax().Seconds(),
+			StdDevSeconds: r.OpStdDev().Seconds(),
+			ThroughputOps: r.Throughput(),
+		},
+	}
+}
+
+// gitSHA returns the current commit hash, or "" if the binary isn't running from inside a git
+// checkout (e.g. a packaged release) or git isn't installed.
+func gitSHA() string {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func buildReport(cfg Config, cpuResults, ioResults []Result) runReport {
+	cpu := make([]modelReport, len(cpuResults))
+	for i, r := range cpuResults {
+		cpu[i] = buildModelReport(r)
+	}
+	var io []modelReport
+	if len(ioResults) > 0 {
+		io = make([]modelReport, len(ioResults))
+		for i, r := range ioResults {
+			io[i] = buildModelReport(r)
+		}
+	}
+	hostname, _ := os.Hostname()
+	return runReport{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		GitSHA:      gitSHA(),
+		Hostname:    hostname,
+		GOMAXPROCS:  runtime.GOMAXPROCS(0),
+		NumCPU:      runtime.NumCPU(),
+		Config:      cfg,
+		CPUBound:    cpu,
+		IOBound:     io,
+	}
+}
+
+// reportCSV flattens a runReport into one row per model, every row sharing the same column count
+// (including the run metadata, repeated on each row) so the output parses cleanly with a plain
+// encoding/csv.Reader.ReadAll() rather than needing a comment-aware reader. The full per-run
+// sample list isn't included (only the computed summary) to keep rows a fixed width; --compare
+// reads the sibling --output=json form for that.
+func reportCSV(report runReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := []string{
+		"generated_at", "git_sha", "hostname", "gomaxprocs",
+		"group", "model", "runs", "mean_s", "p50_s", "p90_s", "p95_s", "p99_s", "min_s", "max_s", "stddev_s", "throughput_ops_s",
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	writeGroup := func(group string, models []modelReport) error {
+		for _, m := range models {
+			s := m.OpLatency
+			err := w.Write([]string{
+				report.GeneratedAt, report.GitSHA, report.Hostname, strconv.Itoa(report.GOMAXPROCS),
+				group, m.Model, strconv.Itoa(len(m.RunSeconds)),
+				strconv.FormatFloat(s.MeanSeconds, 'f', 6, 64),
+				strconv.FormatFloat(s.P50Seconds, 'f', 6, 64),
+				strconv.FormatFloat(s.P90Seconds, 'f', 6, 64),
+				strconv.FormatFloat(s.P95Seconds, 'f', 6, 64),
+				strconv.FormatFloat(s.P99Seconds, 'f', 6, 64),
+				strconv.FormatFloat(s.MinSeconds, 'f', 6, 64),
+				strconv.FormatFloat(s.MaxSeconds, 'f', 6, 64),
+				strconv.FormatFloat(s.StdDevSeconds, 'f', 6, 64),
+				strconv.FormatFloat(s.ThroughputOps, 'f', 1, 64),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := writeGroup("cpu", report.CPUBound); err != nil {
+		return nil, err
+	}
+	if err := writeGroup("io", report.IOBound); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeReport renders report per cfg.Output and writes it to cfg.OutputFile, or stdout if unset.
+func writeReport(cfg Config, report runReport) error {
+	var data []byte
+	var err error
+	switch cfg.Output {
+	case "json":
+		data, err = json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+	case "csv":
+		data, err = reportCSV(report)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown --output %q (want text|json|csv)", cfg.Output)
+	}
+	if cfg.OutputFile == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(cfg.OutputFile, data, 0o644)
+}
+
+// loadReport reads back a report previously written by --output=json, for use as a --compare
+// baseline. CSV reports are lossy (no raw samples) so --compare only accepts JSON.
+func loadReport(path string) (runReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return runReport{}, err
+	}
+	var report runReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return runReport{}, err
+	}
+	return report, nil
+}
+
+// mannWhitneyP returns the two-sided p-value for the Mann-Whitney U test comparing samples a and
+// b, via the normal approximation (no tie correction; fine at the repeat counts this tool uses).
+// A small p-value means the two sample sets likely come from different distributions, i.e. the
+// run-to-run timing shifted rather than just noise.
+func mannWhitneyP(a, b []float64) float64 {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type sample struct {
+		v   float64
+		grp int
+	}
+	combined := make([]sample, 0, n1+n2)
+	for _, v := range a {
+		combined = append(combined, sample{v, 0})
+	}
+	for _, v := range b {
+		combined = append(combined, sample{v, 1})
+	}
+	sort.Slice(combined, func(i, j int) bool { return combined[i].v < combined[j].v })
+
+	ranks := make([]float64, len(combined))
+	for i := 0; i < len(combined); {
+		j := i
+		for j+1 < len(combined) && combined[j+1].v == combined[i].v {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			ranks[k] = rank
+		}
+		i = j + 1
+	}
+
+	var r1 float64
+	for i, c := range combined {
+		if c.grp == 0 {
+			r1 += ranks[i]
+		}
+	}
+	u1 := r1 - float64(n1*(n1+1))/2
+	u2 := float64(n1*n2) - u1
+	u := math.Min(u1, u2)
+
+	meanU := float64(n1*n2) / 2
+	sigmaU := math.Sqrt(float64(n1*n2*(n1+n2+1)) / 12)
+	if sigmaU == 0 {
+		return 1
+	}
+	z := (u - meanU) / sigmaU
+	p := 2 * (1 - 0.5*math.Erfc(-math.Abs(z)/math.Sqrt2))
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+func fmtSecFloat(s float64) string {
+	return fmt.Sprintf("%.6f s", s)
+}
+
+// printCompareTable prints a baseline-vs-current delta for every model present in both reports,
+// flagging the ones whose per-run timing shifted significantly per a Mann-Whitney U test. It
+// always writes to w, never to the report's own output stream: --output=json|csv is meant to be
+// piped straight into a parser, and this human-readable table would otherwise corrupt that
+// stream when --compare is also passed.
+func printCompareTable(w io.Writer, title string, baseline, current []modelReport, sigThreshold float64) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, title)
+	fmt.Fprintln(w, "-----------------------------------")
+	fmt.Fprintf(w, "%-12s %14s %14s %10s %10s %4s\n", "Model", "baseline p50", "current p50", "delta", "p-value", "sig")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------------")
+
+	byModel := make(map[string]modelReport, len(baseline))
+	for _, m := range baseline {
+		byModel[m.Model] = m
+	}
+	for _, cur := range current {
+		base, ok := byModel[cur.Model]
+		if !ok {
+			continue
+		}
+		var pctDelta float64
+		if base.OpLatency.P50Seconds != 0 {
+			pctDelta = (cur.OpLatency.P50Seconds - base.OpLatency.P50Seconds) / base.OpLatency.P50Seconds * 100
+		}
+		p := mannWhitneyP(base.RunSeconds, cur.RunSeconds)
+		sig := ""
+		if p <= sigThreshold {
+			sig = "*"
+		}
+		fmt.Fprintf(w, "%-12s %14s %14s %+9.1f%% %10.4f %4s\n",
+			cur.Model,
+			fmtSecFloat(base.OpLatency.P50Seconds),
+			fmtSecFloat(cur.OpLatency.P50Seconds),
+			pctDelta,
+			p,
+			sig,
+		)
 	}
-	return Result{Model: label, Runs: runs}
 }
 
 func main() {
@@ -361,42 +1815,125 @@ func main() {
 			cpuChild(units)
 			return
 		case "io-child":
-			if len(os.Args) != 5 {
+			if len(os.Args) != 8 {
 				os.Exit(2)
 			}
-			addr := os.Args[2]
-			ps, _ := strconv.Atoi(os.Args[3])
-			tm, _ := strconv.Atoi(os.Args[4])
-			ioChild(addr, ps, tm)
+			workloadName := os.Args[2]
+			target := os.Args[3]
+			ps, _ := strconv.Atoi(os.Args[4])
+			tm, _ := strconv.Atoi(os.Args[5])
+			httpMethod := os.Args[6]
+			blobSizeMiB, _ := strconv.Atoi(os.Args[7])
+			ioChild(workloadName, target, ps, tm, httpMethod, blobSizeMiB)
 			return
 		}
 	}
 
 	cfg := parseArgs()
+	switch cfg.Output {
+	case "text", "json", "csv":
+	default:
+		fmt.Printf("unknown --output %q (want text|json|csv)\n", cfg.Output)
+		os.Exit(1)
+	}
+	switch cfg.IOMode {
+	case "dial-per-op", "keepalive", "pipeline":
+	default:
+		fmt.Printf("unknown --io-mode %q (want dial-per-op|keepalive|pipeline)\n", cfg.IOMode)
+		os.Exit(1)
+	}
+	if cfg.Workload == "udp" && cfg.IOMode == "pipeline" {
+		// udp's echoConn.Pipeline correlates the Nth response read back with the Nth write on the
+		// assumption the transport preserves ordering, which tcp-echo/unix guarantee and UDP does
+		// not: a reordered datagram would silently pair the wrong latency with the wrong op.
+		// keepalive is unaffected (one outstanding op per connection at a time) so only reject
+		// pipeline.
+		fmt.Println("--io-mode=pipeline is not supported with --workload=udp: UDP doesn't preserve datagram ordering, so pipelined responses can't be reliably correlated with their writes")
+		os.Exit(1)
+	}
+	printText := cfg.Output == "text"
 
-	fmt.Printf("Config: tasks=%d, concurrency=%d, repeats=%d\n\n", cfg.Tasks, cfg.Concurrency, cfg.Repeats)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	fmt.Println("CPU-bound benchmark (pure loop; Go has no GIL)")
+	if printText {
+		fmt.Printf("Config: tasks=%d, concurrency=%d, repeats=%d\n\n", cfg.Tasks, cfg.Concurrency, cfg.Repeats)
+		fmt.Println("CPU-bound benchmark (pure loop; Go has no GIL)")
+	}
+	cpuProcReg := newChildRegistry()
 	cpuResults := []Result{
-		runRepeated(cfg, "threads", func() { cpuThreads(cfg) }),
-		runRepeated(cfg, "processes", func() { cpuProcesses(cfg) }),
-		runRepeated(cfg, "goroutines", func() { cpuGoroutines(cfg) }),
+		runRepeated(ctx, cfg, "threads", nil, func(ctx context.Context) []time.Duration { return cpuThreads(ctx, cfg) }),
+		runRepeated(ctx, cfg, "processes", cpuProcReg, func(ctx context.Context) []time.Duration { return cpuProcesses(ctx, cfg, cpuProcReg) }),
+		runRepeated(ctx, cfg, "goroutines", nil, func(ctx context.Context) []time.Duration { return cpuGoroutines(ctx, cfg) }),
+	}
+	if printText {
+		printTable("CPU-bound results (lower is better)", cpuResults)
+		if cfg.Report == "table" || cfg.Report == "both" {
+			printLatencyStats("CPU-bound per-task latency profile", cpuResults)
+		}
+		if cfg.Report == "histogram" || cfg.Report == "both" {
+			printHistograms("CPU-bound per-task latency histogram", cpuResults)
+		}
+		printResourceStats("CPU-bound resource usage (median / peak per measured run)", cpuResults)
 	}
-	printTable("CPU-bound results (lower is better)", cpuResults)
 
-	fmt.Println("\nI/O-bound benchmark (local TCP echo)")
-	srv, err := startEchoServer(cfg)
-	if err != nil {
-		fmt.Println("Failed to start echo server:", err)
-		os.Exit(1)
+	var ioResults []Result
+	if ctx.Err() == nil {
+		wl, err := newWorkload(cfg.Workload)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		target, teardown, err := wl.Start(cfg)
+		if err != nil {
+			fmt.Println("Failed to start workload:", err)
+			os.Exit(1)
+		}
+		defer teardown()
+
+		if printText {
+			fmt.Printf("\nI/O-bound benchmark (workload=%s, target=%s, io-mode=%s)\n", cfg.Workload, target, cfg.IOMode)
+		}
+		ioProcReg := newChildRegistry()
+		ioResults = []Result{
+			runRepeated(ctx, cfg, "threads", nil, func(ctx context.Context) []time.Duration { return ioThreads(ctx, cfg, wl, target) }),
+			runRepeated(ctx, cfg, "processes", ioProcReg, func(ctx context.Context) []time.Duration { return ioProcesses(ctx, cfg, target, ioProcReg) }),
+			runRepeated(ctx, cfg, "goroutines", nil, func(ctx context.Context) []time.Duration { return ioGoroutines(ctx, cfg, wl, target) }),
+		}
+		if printText {
+			printTable("I/O-bound results (lower is better)", ioResults)
+			if cfg.Report == "table" || cfg.Report == "both" {
+				printLatencyStats("I/O-bound per-op latency profile", ioResults)
+			}
+			if cfg.Report == "histogram" || cfg.Report == "both" {
+				printHistograms("I/O-bound per-op latency histogram", ioResults)
+			}
+			printResourceStats("I/O-bound resource usage (median / peak per measured run)", ioResults)
+		}
+	}
+
+	report := buildReport(cfg, cpuResults, ioResults)
+
+	if !printText {
+		if err := writeReport(cfg, report); err != nil {
+			fmt.Println("Failed to write report:", err)
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Compare != "" {
+		baseline, err := loadReport(cfg.Compare)
+		if err != nil {
+			fmt.Println("Failed to load --compare baseline:", err)
+			os.Exit(1)
+		}
+		printCompareTable(os.Stderr, "CPU-bound regression delta (baseline -> current)", baseline.CPUBound, report.CPUBound, cfg.SigThreshold)
+		if len(report.IOBound) > 0 {
+			printCompareTable(os.Stderr, "I/O-bound regression delta (baseline -> current)", baseline.IOBound, report.IOBound, cfg.SigThreshold)
+		}
 	}
-	defer srv.Close()
 
-	payload := make([]byte, cfg.PayloadSize)
-	ioResults := []Result{
-		runRepeated(cfg, "threads", func() { ioThreads(cfg, srv.addr, payload) }),
-		runRepeated(cfg, "processes", func() { ioProcesses(cfg, srv.addr) }),
-		runRepeated(cfg, "goroutines", func() { ioGoroutines(cfg, srv.addr, payload) }),
+	if printText && ctx.Err() != nil {
+		fmt.Println("\nStopped early (signal received or run-time deadline elapsed); results above reflect completed work only.")
 	}
-	printTable("I/O-bound results (lower is better)", ioResults)
 }
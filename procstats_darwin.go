@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readProcStats on macOS shells out to ps for RSS and cumulative CPU time. Per-process open FD
+// and thread counts aren't available from the stdlib without cgo, so those fields are left at
+// zero (best effort, same spirit as this tool's other OS-dependent knobs like --backlog).
+func readProcStats(pid int) (procStats, error) {
+	var ps procStats
+
+	out, err := exec.Command("ps", "-o", "rss=,time=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ps, err
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 {
+		return ps, fmt.Errorf("unexpected ps output for pid %d", pid)
+	}
+
+	kb, _ := strconv.ParseUint(fields[0], 10, 64)
+	ps.RSSBytes = kb * 1024
+	ps.CPUTime = parsePSTime(fields[1])
+	return ps, nil
+}
+
+// parsePSTime parses ps's [[dd-]hh:]mm:ss(.ss) cumulative CPU time format.
+func parsePSTime(s string) time.Duration {
+	var days int
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		days, _ = strconv.Atoi(s[:i])
+		s = s[i+1:]
+	}
+
+	parts := strings.Split(s, ":")
+	var hours, minutes int
+	var seconds float64
+	switch len(parts) {
+	case 3:
+		hours, _ = strconv.Atoi(parts[0])
+		minutes, _ = strconv.Atoi(parts[1])
+		seconds, _ = strconv.ParseFloat(parts[2], 64)
+	case 2:
+		minutes, _ = strconv.Atoi(parts[0])
+		seconds, _ = strconv.ParseFloat(parts[1], 64)
+	default:
+		seconds, _ = strconv.ParseFloat(s, 64)
+	}
+
+	return time.Duration(days)*24*time.Hour +
+		time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second))
+}
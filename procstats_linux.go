@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicksPerSec approximates the kernel's USER_HZ, which is 100 on effectively every Linux
+// platform Go supports; reading the real value requires cgo's sysconf(_SC_CLK_TCK).
+const clockTicksPerSec = 100
+
+// readProcStats reads per-process CPU time, RSS, thread count, and open FD count from procfs.
+func readProcStats(pid int) (procStats, error) {
+	var ps procStats
+
+	statBytes, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return ps, err
+	}
+	stat := string(statBytes)
+	// Fields after the command name (which may itself contain spaces or parens) start right
+	// after the last ')'.
+	paren := strings.LastIndexByte(stat, ')')
+	if paren < 0 {
+		return ps, fmt.Errorf("unexpected /proc/%d/stat format", pid)
+	}
+	fields := strings.Fields(stat[paren+1:])
+	// fields[0] is state (field 3 overall); utime/stime are fields 14/15 overall, i.e. indices
+	// 11/12 here.
+	if len(fields) < 13 {
+		return ps, fmt.Errorf("short /proc/%d/stat", pid)
+	}
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	ps.CPUTime = time.Duration(utime+stime) * time.Second / clockTicksPerSec
+
+	statusFile, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return ps, err
+	}
+	defer statusFile.Close()
+	scanner := bufio.NewScanner(statusFile)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "VmRSS:"):
+			if fields := strings.Fields(line); len(fields) >= 2 {
+				kb, _ := strconv.ParseUint(fields[1], 10, 64)
+				ps.RSSBytes = kb * 1024
+			}
+		case strings.HasPrefix(line, "Threads:"):
+			if fields := strings.Fields(line); len(fields) >= 2 {
+				ps.NumThreads, _ = strconv.Atoi(fields[1])
+			}
+		}
+	}
+
+	if entries, err := os.ReadDir(fmt.Sprintf("/proc/%d/fd", pid)); err == nil {
+		ps.NumFDs = len(entries)
+	}
+
+	return ps, nil
+}
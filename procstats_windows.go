@@ -0,0 +1,11 @@
+package main
+
+import "fmt"
+
+// readProcStats always returns an error on Windows: per-process CPU/RSS/thread/FD counters
+// require the Windows API (e.g. via golang.org/x/sys/windows), which isn't vendored into this
+// tree. On this platform the resource sampler reports only the goroutine count and the Go
+// runtime's thread-creation count; CPU%, RSS and FD columns read zero.
+func readProcStats(pid int) (procStats, error) {
+	return procStats{}, fmt.Errorf("resource sampling is not implemented on windows")
+}